@@ -0,0 +1,208 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Syntax limits from RFC 5321/5322
+const (
+	maxLocalPartLength = 64
+	maxDomainLength    = 253
+	maxLabelLength     = 63
+)
+
+// ErrUnknownTLD is returned when an address's domain ends in a TLD that
+// doesn't appear in the bundled IANA root zone list
+var ErrUnknownTLD = errors.New("domain has an unrecognized top level domain")
+
+// localPartAllowed matches the unquoted local-part characters RFC 5321
+// permits outside of a quoted string
+const localPartAllowed = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&'*+-/=?^_`{|}~."
+
+// Address represents a fully parsed and syntax-validated email address
+type Address struct {
+	Address    string // The full email address, with its domain lower-cased
+	Username   string // The local-part of the address
+	Domain     string // The domain, lower-cased
+	Suggestion string // A likely-intended domain, populated when Domain appears to be a typo
+}
+
+// ParseAddress performs full RFC 5321/5322 syntax validation on email,
+// including local-part and domain length limits, character restrictions,
+// IDN/punycode domain validation and a bundled-TLD check. When the domain
+// closely matches a well-known free-mail provider, Suggestion is populated
+// with the likely intended domain rather than failing the parse outright
+func ParseAddress(email string) (*Address, error) {
+	at := strings.LastIndex(email, "@")
+	if at < 1 || at == len(email)-1 {
+		return nil, errors.New("address is missing a local-part or domain")
+	}
+
+	username, domain := email[:at], email[at+1:]
+	domain = strings.ToLower(domain)
+
+	if err := validateLocalPart(username); err != nil {
+		return nil, err
+	}
+
+	ascii, err := validateDomainStructure(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Computed before the TLD check so a typo'd TLD (gmail.con) still
+	// surfaces a Suggestion instead of the suggestion logic being dead code
+	// behind an early ErrUnknownTLD return
+	suggestion := suggestDomain(domain)
+
+	tld := ascii[strings.LastIndex(ascii, ".")+1:]
+	if !validTLDs[tld] {
+		// Returns a partial Address, rather than just the error, so callers
+		// (Verify's parse-error branch) can still surface Suggestion
+		return &Address{Domain: domain, Suggestion: suggestion}, ErrUnknownTLD
+	}
+
+	return &Address{
+		Address:    username + "@" + domain,
+		Username:   username,
+		Domain:     domain,
+		Suggestion: suggestion,
+	}, nil
+}
+
+// validateLocalPart enforces the 64 octet length limit and rejects
+// characters that aren't allowed outside of a quoted local-part
+func validateLocalPart(username string) error {
+	if len(username) == 0 {
+		return errors.New("local-part is empty")
+	}
+	if len(username) > maxLocalPartLength {
+		return fmt.Errorf("local-part exceeds %d octets", maxLocalPartLength)
+	}
+
+	// A quoted local-part ("john doe"@example.com) may contain characters
+	// that would otherwise be disallowed, so skip character validation
+	if strings.HasPrefix(username, `"`) && strings.HasSuffix(username, `"`) {
+		return nil
+	}
+
+	for _, r := range username {
+		if !strings.ContainsRune(localPartAllowed, r) {
+			return fmt.Errorf("local-part contains disallowed character %q", r)
+		}
+	}
+	if strings.HasPrefix(username, ".") || strings.HasSuffix(username, ".") || strings.Contains(username, "..") {
+		return errors.New("local-part has a misplaced or repeated '.'")
+	}
+	return nil
+}
+
+// validateDomainStructure enforces the 253 octet / 63 octet label length
+// limits and resolves IDN labels to punycode, returning the ASCII form for
+// the TLD check that follows. It does not check TLD validity, so a typo'd
+// but otherwise well-formed domain can still reach suggestDomain
+func validateDomainStructure(domain string) (string, error) {
+	if len(domain) == 0 {
+		return "", errors.New("domain is empty")
+	}
+
+	ascii, err := idna.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("domain is not a valid IDN: %w", err)
+	}
+
+	if len(ascii) > maxDomainLength {
+		return "", fmt.Errorf("domain exceeds %d octets", maxDomainLength)
+	}
+
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return "", errors.New("domain is missing a top level domain")
+	}
+	for _, label := range labels {
+		if label == "" {
+			return "", errors.New("domain has an empty label")
+		}
+		if len(label) > maxLabelLength {
+			return "", fmt.Errorf("domain label %q exceeds %d octets", label, maxLabelLength)
+		}
+	}
+
+	return ascii, nil
+}
+
+// suggestDomain returns the closest bundled free-mail domain to domain when
+// one exists within a Damerau-Levenshtein distance of 2, or "" otherwise.
+// Ties are broken in favor of the smaller edit distance, then by popularity
+func suggestDomain(domain string) string {
+	if _, ok := popularDomainRank[domain]; ok {
+		return "" // domain is already a recognized popular domain
+	}
+
+	const maxDistance = 2
+	best := ""
+	bestDistance := maxDistance + 1
+	bestRank := int(^uint(0) >> 1) // max int
+
+	for _, candidate := range popularDomains {
+		dist := damerauLevenshtein(domain, candidate)
+		if dist > maxDistance || dist > bestDistance {
+			continue
+		}
+		rank := popularDomainRank[candidate]
+		if dist < bestDistance || (dist == bestDistance && rank < bestRank) {
+			best, bestDistance, bestRank = candidate, dist, rank
+		}
+	}
+	return best
+}
+
+// damerauLevenshtein computes the Damerau-Levenshtein edit distance
+// (insertions, deletions, substitutions and adjacent transpositions)
+// between two case-folded strings
+func damerauLevenshtein(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	ra, rb := []rune(a), []rune(b)
+
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min2(d[i][j], d[i-2][j-2]+cost) // transposition
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func min2(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func min3(a, b, c int) int {
+	return min2(min2(a, b), c)
+}
@@ -0,0 +1,87 @@
+package verifier
+
+import "testing"
+
+func TestDamerauLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"gmail.com", "gmail.com", 0},
+		{"gmail.com", "gmial.com", 1}, // adjacent transposition
+		{"gmail.com", "gmail.con", 1}, // substitution
+		{"gmail.com", "gmail.co", 1},  // deletion
+		{"gmail.com", "gmails.com", 1},
+		{"", "abc", 3},
+		{"abc", "", 3},
+	}
+	for _, c := range cases {
+		if got := damerauLevenshtein(c.a, c.b); got != c.want {
+			t.Errorf("damerauLevenshtein(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestSuggestDomain(t *testing.T) {
+	cases := []struct {
+		domain string
+		want   string
+	}{
+		{"gmail.com", ""}, // already a recognized popular domain
+		{"gmial.com", "gmail.com"},
+		{"gmail.con", "gmail.com"},
+		{"yahooo.com", "yahoo.com"},
+		{"totallyrandomdomainnoonewoulduse.com", ""}, // too far from anything
+	}
+	for _, c := range cases {
+		if got := suggestDomain(c.domain); got != c.want {
+			t.Errorf("suggestDomain(%q) = %q, want %q", c.domain, got, c.want)
+		}
+	}
+}
+
+func TestParseAddressSuggestsOnTLDTypo(t *testing.T) {
+	// "gmail.con" is structurally valid but "con" isn't a recognized TLD;
+	// the suggestion logic must still run and be reachable via the partial
+	// Address returned alongside ErrUnknownTLD
+	address, err := ParseAddress("john@gmail.con")
+	if err != ErrUnknownTLD {
+		t.Fatalf("expected ErrUnknownTLD, got %v", err)
+	}
+	if address == nil {
+		t.Fatal("expected a partial Address to be returned alongside ErrUnknownTLD")
+	}
+	if address.Suggestion != "gmail.com" {
+		t.Errorf("Suggestion = %q, want %q", address.Suggestion, "gmail.com")
+	}
+}
+
+func TestParseAddressAcceptsModernTLD(t *testing.T) {
+	address, err := ParseAddress("hello@example.ai")
+	if err != nil {
+		t.Fatalf("ParseAddress returned unexpected error: %v", err)
+	}
+	if address.Domain != "example.ai" {
+		t.Errorf("Domain = %q, want %q", address.Domain, "example.ai")
+	}
+}
+
+func TestValidateLocalPart(t *testing.T) {
+	cases := []struct {
+		username string
+		wantErr  bool
+	}{
+		{"john.doe", false},
+		{`"john doe"`, false},
+		{"", true},
+		{".john", true},
+		{"john.", true},
+		{"john..doe", true},
+	}
+	for _, c := range cases {
+		err := validateLocalPart(c.username)
+		if (err != nil) != c.wantErr {
+			t.Errorf("validateLocalPart(%q) error = %v, wantErr %v", c.username, err, c.wantErr)
+		}
+	}
+}
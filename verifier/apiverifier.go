@@ -0,0 +1,138 @@
+package verifier
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// gmailDialTimeout bounds how long Check will wait to connect to and
+// complete STARTTLS negotiation with a Gmail MX host before giving up,
+// since an unresponsive host would otherwise hang the calling goroutine
+// indefinitely
+const gmailDialTimeout = 10 * time.Second
+
+// netLookupMX is a var indirection over net.LookupMX so tests can stub out
+// DNS resolution
+var netLookupMX = net.LookupMX
+
+// APIVerifier defines a provider-specific verification strategy that can be
+// used in place of a conventional SMTP RCPT TO probe. Major mailbox
+// providers increasingly reject, rate limit or greylist generic SMTP
+// probing, so registering an APIVerifier for a given MX host lets the
+// worker short-circuit to a more reliable check.
+type APIVerifier interface {
+	// Supports reports whether this APIVerifier knows how to validate
+	// addresses hosted on the given MX host
+	Supports(mxHost string) bool
+
+	// Check performs the provider specific validation for username@domain
+	// and returns a Lookup populated with the outcome
+	Check(domain, username string) (*Lookup, error)
+}
+
+// gmailAPIVerifier validates addresses hosted on Google's MX servers by
+// opening a STARTTLS SMTP session and relying on Gmail's early RCPT TO
+// rejection behavior, which is far less likely to be greylisted than a
+// plaintext probe
+type gmailAPIVerifier struct {
+	hostname   string
+	sourceAddr string
+	dialer     ContextDialer
+	rootCAs    *x509.CertPool // trusted roots for STARTTLS; nil means the system roots
+}
+
+// NewGmailAPIVerifier returns an APIVerifier tuned to Gmail/Google Workspace
+// MX hosts. When dialer is nil, a plain *net.Dialer is used; callers that
+// configured WithProxy/WithDialer on their Verifier should pass the same
+// dialer here so Gmail probes egress through it
+func NewGmailAPIVerifier(hostname, sourceAddr string, dialer ContextDialer) APIVerifier {
+	if dialer == nil {
+		dialer = &net.Dialer{Timeout: gmailDialTimeout}
+	}
+	return &gmailAPIVerifier{hostname: hostname, sourceAddr: sourceAddr, dialer: dialer}
+}
+
+// Supports matches any MX host served by Google's mail infrastructure
+func (g *gmailAPIVerifier) Supports(mxHost string) bool {
+	host := strings.ToLower(mxHost)
+	return strings.Contains(host, "google.com") || strings.Contains(host, "googlemail.com")
+}
+
+// Check dials the MX host on port 25 - the port real Gmail/Workspace MX
+// hosts actually accept inbound mail on, unlike 465 implicit TLS - upgrades
+// to TLS via STARTTLS and issues RCPT TO, which Gmail answers synchronously
+// and reliably unlike a plaintext probe. The whole exchange is bounded by
+// gmailDialTimeout so an unresponsive host can't hang the caller forever.
+// Rate limiting and backoff around repeated calls to Check are the caller's
+// responsibility (lookupAddress applies the verifier's shared Limiter and
+// backoffTracker around every APIVerifier call), since Check itself has no
+// way to distinguish a one-off probe from a long-lived stream
+func (g *gmailAPIVerifier) Check(domain, username string) (*Lookup, error) {
+	mxs, err := lookupMXHosts(domain)
+	if err != nil || len(mxs) == 0 {
+		return nil, err
+	}
+	mxHost := mxs[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), gmailDialTimeout)
+	defer cancel()
+
+	conn, err := g.dialer.DialContext(ctx, "tcp", mxHost+":25")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, mxHost)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	// Hello must be called before any other Client method - including
+	// StartTLS, which otherwise sends its own implicit EHLO/HELO first and
+	// makes every subsequent Hello call fail with "Hello called after other
+	// methods"
+	if err := client.Hello(g.hostname); err != nil {
+		return nil, err
+	}
+	if err := client.StartTLS(&tls.Config{ServerName: mxHost, RootCAs: g.rootCAs}); err != nil {
+		return nil, err
+	}
+	if err := client.Mail(g.sourceAddr); err != nil {
+		return nil, err
+	}
+
+	address := username + "@" + domain
+	deliverable := client.Rcpt(address) == nil
+
+	return &Lookup{
+		Address:     address,
+		Username:    username,
+		Domain:      domain,
+		HostExists:  true,
+		Deliverable: deliverable,
+	}, nil
+}
+
+// lookupMXHosts resolves the MX hosts for domain, stripped of their
+// trailing priority and dot
+func lookupMXHosts(domain string) ([]string, error) {
+	mxs, err := netLookupMX(domain)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(mxs))
+	for _, mx := range mxs {
+		hosts = append(hosts, strings.TrimSuffix(mx.Host, "."))
+	}
+	return hosts, nil
+}
@@ -0,0 +1,205 @@
+package verifier
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGmailAPIVerifierSupports(t *testing.T) {
+	g := NewGmailAPIVerifier("localhost", "probe@localhost", nil)
+	cases := []struct {
+		mxHost string
+		want   bool
+	}{
+		{"aspmx.l.google.com", true},
+		{"ALT1.ASPMX.L.GOOGLE.COM", true},
+		{"gmail-smtp-in.l.googlemail.com", true},
+		{"mx.other-provider.com", false},
+	}
+	for _, c := range cases {
+		if got := g.Supports(c.mxHost); got != c.want {
+			t.Errorf("Supports(%q) = %v, want %v", c.mxHost, got, c.want)
+		}
+	}
+}
+
+func TestGmailAPIVerifierCheckMXLookupFailure(t *testing.T) {
+	old := netLookupMX
+	defer func() { netLookupMX = old }()
+	wantErr := errors.New("no such host")
+	netLookupMX = func(name string) ([]*net.MX, error) { return nil, wantErr }
+
+	g := NewGmailAPIVerifier("localhost", "probe@localhost", nil)
+	lookup, err := g.Check("example.com", "john")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Check error = %v, want %v", err, wantErr)
+	}
+	if lookup != nil {
+		t.Fatalf("expected a nil Lookup on MX lookup failure, got %+v", lookup)
+	}
+}
+
+// fakeDialer redirects every DialContext call to a fixed address, letting
+// tests stand in a local fake MX server for the real (unresolvable) one
+// Check's stubbed MX lookup returns
+type fakeDialer struct{ addr string }
+
+func (f fakeDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, network, f.addr)
+}
+
+// selfSignedCert generates an in-memory TLS certificate for "mx.example.com"
+// so the fake server below can negotiate STARTTLS without touching disk
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mx.example.com"},
+		DNSNames:     []string{"mx.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create test certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// fakeGmailServer speaks just enough SMTP+STARTTLS to drive Check through a
+// full EHLO / STARTTLS / re-EHLO / MAIL FROM / RCPT TO exchange, accepting
+// or rejecting RCPT TO per acceptRcpt
+func fakeGmailServer(t *testing.T, cert tls.Certificate, acceptRcpt bool) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake MX server: %v", err)
+	}
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 mx.example.com ESMTP ready")
+
+		tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			upper := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(upper, "EHLO"):
+				tp.PrintfLine("250-mx.example.com at your service")
+				tp.PrintfLine("250 STARTTLS")
+			case strings.HasPrefix(upper, "STARTTLS"):
+				tp.PrintfLine("220 Go ahead")
+				tlsConn := tls.Server(conn, tlsConfig)
+				if err := tlsConn.Handshake(); err != nil {
+					return
+				}
+				conn = tlsConn
+				tp = textproto.NewConn(conn)
+			case strings.HasPrefix(upper, "MAIL FROM"):
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(upper, "RCPT TO"):
+				if acceptRcpt {
+					tp.PrintfLine("250 OK")
+				} else {
+					tp.PrintfLine("550 no such user")
+				}
+			case strings.HasPrefix(upper, "QUIT"):
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("500 unrecognized command")
+			}
+		}
+	}()
+	return ln
+}
+
+func checkAgainstFakeServer(t *testing.T, acceptRcpt bool) *Lookup {
+	t.Helper()
+	old := netLookupMX
+	defer func() { netLookupMX = old }()
+	netLookupMX = func(name string) ([]*net.MX, error) {
+		return []*net.MX{{Host: "mx.example.com.", Pref: 10}}, nil
+	}
+
+	cert := selfSignedCert(t)
+	ln := fakeGmailServer(t, cert, acceptRcpt)
+	defer ln.Close()
+
+	g := NewGmailAPIVerifier("localhost", "probe@localhost", fakeDialer{addr: ln.Addr().String()})
+
+	// The fake server's certificate is self-signed, so trust it explicitly
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("failed to parse test certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	g.(*gmailAPIVerifier).rootCAs = roots
+
+	lookup, err := g.Check("example.com", "john")
+	if err != nil {
+		t.Fatalf("Check returned unexpected error: %v", err)
+	}
+	return lookup
+}
+
+func TestGmailAPIVerifierCheckDeliverable(t *testing.T) {
+	lookup := checkAgainstFakeServer(t, true)
+	if !lookup.Deliverable {
+		t.Fatal("expected Deliverable to be true when RCPT TO is accepted")
+	}
+	if lookup.Address != "john@example.com" {
+		t.Errorf("Address = %q, want %q", lookup.Address, "john@example.com")
+	}
+}
+
+func TestGmailAPIVerifierCheckUndeliverable(t *testing.T) {
+	lookup := checkAgainstFakeServer(t, false)
+	if lookup.Deliverable {
+		t.Fatal("expected Deliverable to be false when RCPT TO is rejected")
+	}
+}
+
+func TestLookupMXHosts(t *testing.T) {
+	old := netLookupMX
+	defer func() { netLookupMX = old }()
+	netLookupMX = func(name string) ([]*net.MX, error) {
+		return []*net.MX{{Host: "mx1.example.com.", Pref: 10}, {Host: "mx2.example.com.", Pref: 20}}, nil
+	}
+
+	hosts, err := lookupMXHosts("example.com")
+	if err != nil {
+		t.Fatalf("lookupMXHosts returned unexpected error: %v", err)
+	}
+	want := []string{"mx1.example.com", "mx2.example.com"}
+	if len(hosts) != len(want) || hosts[0] != want[0] || hosts[1] != want[1] {
+		t.Errorf("lookupMXHosts = %v, want %v", hosts, want)
+	}
+}
@@ -0,0 +1,97 @@
+package verifier
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache defines a pluggable memoization backend used by verifier to
+// remember MX lookups, catch-all and disposable-domain verdicts and
+// per-address deliverability, avoiding repeated DNS lookups and SMTP probes. Implementations backed by Redis, memcache or
+// similar shared stores can be swapped in by satisfying this interface
+type Cache interface {
+	// Get returns the cached value for key and whether it was found and
+	// has not yet expired
+	Get(key string) ([]byte, bool)
+
+	// Set stores val under key for the given ttl. A ttl of zero means the
+	// entry never expires
+	Set(key string, val []byte, ttl time.Duration)
+}
+
+// cacheEntry holds a cached value alongside its expiration
+type cacheEntry struct {
+	key     string
+	val     []byte
+	expires time.Time // zero value means no expiration
+}
+
+// lruCache is the default in-memory Cache implementation, bounded by a
+// maximum entry count and evicting the least recently used entry once full
+type lruCache struct {
+	mu       sync.Mutex
+	maxItems int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache returns an in-memory Cache that holds at most maxItems
+// entries, evicting the least recently used entry once full. A maxItems of
+// zero or less means unbounded
+func NewLRUCache(maxItems int) Cache {
+	return &lruCache{
+		maxItems: maxItems,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key, removing it first if it has expired
+func (c *lruCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.val, true
+}
+
+// Set stores val under key for the given ttl, evicting the least recently
+// used entry if the cache is at capacity
+func (c *lruCache) Set(key string, val []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*cacheEntry).val = val
+		el.Value.(*cacheEntry).expires = expires
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&cacheEntry{key: key, val: val, expires: expires})
+	c.items[key] = el
+
+	if c.maxItems > 0 && c.ll.Len() > c.maxItems {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package verifier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacheGetSet(t *testing.T) {
+	c := NewLRUCache(0)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected Get of missing key to report not found")
+	}
+
+	c.Set("a", []byte("1"), 0)
+	val, ok := c.Get("a")
+	if !ok || string(val) != "1" {
+		t.Fatalf("Get(%q) = %q, %v; want %q, true", "a", val, ok, "1")
+	}
+
+	c.Set("a", []byte("2"), 0)
+	if val, ok := c.Get("a"); !ok || string(val) != "2" {
+		t.Fatalf("Get after overwrite = %q, %v; want %q, true", val, ok, "2")
+	}
+}
+
+func TestLRUCacheExpiry(t *testing.T) {
+	c := NewLRUCache(0)
+	c.Set("a", []byte("1"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected expired entry to be evicted on Get")
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), 0)
+
+	// Touching "a" should keep it more recently used than "b"
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to be present")
+	}
+
+	c.Set("c", []byte("3"), 0) // should evict "b", the least recently used
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to have been evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to still be cached")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected c to be cached")
+	}
+}
@@ -0,0 +1,138 @@
+package verifier
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// ContextDialer is satisfied by both *net.Dialer and proxy.Dialer
+// implementations, letting verifier treat a direct dial and a proxied dial
+// identically when opening SMTP connections
+type ContextDialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// ProxySelector chooses a proxy URI for a given destination MX host,
+// allowing callers to rotate egress IPs per domain so a single proxy
+// doesn't accumulate a bad reputation across every probed provider
+type ProxySelector interface {
+	// ProxyURI returns the socks5:// or http:// proxy URI to use when
+	// dialing mxHost, or an empty string to dial directly
+	ProxyURI(mxHost string) string
+}
+
+// dialerFor builds the ContextDialer verifier should use to reach mxHost,
+// honoring a static proxyURI, a per-domain ProxySelector (which takes
+// precedence when set) and the base net.Dialer used for timeouts and local
+// address configuration
+func dialerFor(base *net.Dialer, proxyURI string, selector ProxySelector, mxHost string) (ContextDialer, error) {
+	if base == nil {
+		base = &net.Dialer{Timeout: 10 * time.Second}
+	}
+
+	uri := proxyURI
+	if selector != nil {
+		if selected := selector.ProxyURI(mxHost); selected != "" {
+			uri = selected
+		}
+	}
+	if uri == "" {
+		return base, nil
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URI %q: %w", uri, err)
+	}
+
+	if parsed.Scheme == "http" {
+		return &httpConnectDialer{base: base, proxyAddr: parsed.Host, proxyAuth: parsed.User}, nil
+	}
+
+	dialer, err := proxy.FromURL(parsed, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build proxy dialer for %q: %w", uri, err)
+	}
+
+	contextDialer, ok := dialer.(ContextDialer)
+	if !ok {
+		return nil, fmt.Errorf("proxy dialer for %q does not support DialContext", uri)
+	}
+	return contextDialer, nil
+}
+
+// httpConnectDialer is a ContextDialer that reaches its destination through
+// an HTTP proxy's CONNECT method, the de facto standard for tunneling
+// arbitrary TCP (including SMTP) through an http:// proxy URI. Basic auth is
+// sent when the proxy URI carries userinfo
+type httpConnectDialer struct {
+	base      *net.Dialer
+	proxyAddr string
+	proxyAuth *url.Userinfo
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.base.DialContext(ctx, network, d.proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial proxy %q: %w", d.proxyAddr, err)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.proxyAuth != nil {
+		password, _ := d.proxyAuth.Password()
+		req.SetBasicAuth(d.proxyAuth.Username(), password)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write CONNECT request to %q: %w", d.proxyAddr, err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from %q: %w", d.proxyAddr, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %q refused CONNECT to %q: %s", d.proxyAddr, address, resp.Status)
+	}
+
+	// The destination may write its first bytes (an SMTP banner, say) in
+	// the same TCP segment as the proxy's CONNECT response, in which case
+	// they're already sitting in br's buffer. Replay them before falling
+	// through to raw conn reads, or they'd be silently dropped
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, br: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a net.Conn that first drains bytes already buffered in br
+// - left over from reading an HTTP response off the same connection - before
+// falling through to reading from the underlying conn directly
+type bufferedConn struct {
+	net.Conn
+	br *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.br.Read(p)
+}
@@ -0,0 +1,118 @@
+package verifier
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnectProxy listens once, expects a CONNECT request, and writes back
+// response in one go so the reply and the destination's first bytes may
+// arrive coalesced in a single read, exactly as a real proxy/server pair
+// commonly would
+func fakeConnectProxy(t *testing.T, response string) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to start fake proxy: %v", err)
+	}
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		req.Body.Close()
+		if req.Method != http.MethodConnect {
+			return
+		}
+
+		conn.Write([]byte(response))
+	}()
+	return ln
+}
+
+func TestHTTPConnectDialerReplaysCoalescedBanner(t *testing.T) {
+	// The proxy's "200 Connection established" response and the upstream
+	// SMTP server's banner arrive in the same Write, simulating a proxy
+	// that coalesces both onto the wire together
+	response := "HTTP/1.1 200 Connection established\r\n\r\n220 smtp.example.com ESMTP ready\r\n"
+	ln := fakeConnectProxy(t, response)
+	defer ln.Close()
+
+	d := &httpConnectDialer{base: &net.Dialer{Timeout: 2 * time.Second}, proxyAddr: ln.Addr().String()}
+	conn, err := d.DialContext(context.Background(), "tcp", "smtp.example.com:25")
+	if err != nil {
+		t.Fatalf("DialContext returned error: %v", err)
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("expected to read the coalesced SMTP banner, got error: %v", err)
+	}
+	if got := string(buf[:n]); !strings.Contains(got, "220 smtp.example.com") {
+		t.Fatalf("Read() = %q, want it to contain the SMTP banner", got)
+	}
+}
+
+func TestHTTPConnectDialerRejectsNonOKStatus(t *testing.T) {
+	ln := fakeConnectProxy(t, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+	defer ln.Close()
+
+	d := &httpConnectDialer{base: &net.Dialer{Timeout: 2 * time.Second}, proxyAddr: ln.Addr().String()}
+	if _, err := d.DialContext(context.Background(), "tcp", "smtp.example.com:25"); err == nil {
+		t.Fatal("expected an error for a non-200 CONNECT response")
+	}
+}
+
+func TestDialerForSelectsHTTPConnectDialer(t *testing.T) {
+	dialer, err := dialerFor(nil, "http://proxy.example.com:8080", nil, "mx.example.com")
+	if err != nil {
+		t.Fatalf("dialerFor returned unexpected error: %v", err)
+	}
+	if _, ok := dialer.(*httpConnectDialer); !ok {
+		t.Fatalf("dialerFor(http://...) = %T, want *httpConnectDialer", dialer)
+	}
+}
+
+func TestDialerForNoProxyReturnsBase(t *testing.T) {
+	base := &net.Dialer{Timeout: time.Second}
+	dialer, err := dialerFor(base, "", nil, "mx.example.com")
+	if err != nil {
+		t.Fatalf("dialerFor returned unexpected error: %v", err)
+	}
+	if dialer != ContextDialer(base) {
+		t.Fatal("expected dialerFor with no proxy configured to return the base dialer unchanged")
+	}
+}
+
+func TestDialerForSelectorTakesPrecedence(t *testing.T) {
+	selector := proxySelectorFunc(func(mxHost string) string { return "http://selected.example.com:8080" })
+	dialer, err := dialerFor(nil, "http://static.example.com:8080", selector, "mx.example.com")
+	if err != nil {
+		t.Fatalf("dialerFor returned unexpected error: %v", err)
+	}
+	d, ok := dialer.(*httpConnectDialer)
+	if !ok {
+		t.Fatalf("dialerFor = %T, want *httpConnectDialer", dialer)
+	}
+	if d.proxyAddr != "selected.example.com:8080" {
+		t.Fatalf("proxyAddr = %q, want the selector's choice", d.proxyAddr)
+	}
+}
+
+type proxySelectorFunc func(mxHost string) string
+
+func (f proxySelectorFunc) ProxyURI(mxHost string) string { return f(mxHost) }
@@ -0,0 +1,75 @@
+package verifier
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// netLookupHost and netLookupCNAME are var indirections over the net
+// package resolvers so tests can stub out DNS resolution of MX hosts
+var (
+	netLookupHost  = net.LookupHost
+	netLookupCNAME = net.LookupCNAME
+)
+
+// mxCheck describes the outcome of sanity checking a domain's MX records
+type mxCheck struct {
+	misconfigured bool
+	reason        string
+	records       []string
+}
+
+// checkMX inspects mxs for configurations that are known to make SMTP
+// probing pointless: IP literal hosts, localhost/loopback/private targets,
+// hosts that fail to resolve to an A/AAAA record, and RFC 7505 "null MX"
+// records. The raw host list is always returned so callers can surface it
+// regardless of whether a misconfiguration was found
+func checkMX(mxs []*net.MX) mxCheck {
+	records := make([]string, len(mxs))
+	for i, mx := range mxs {
+		records[i] = strings.TrimSuffix(mx.Host, ".")
+	}
+
+	if len(mxs) == 0 {
+		return mxCheck{records: records}
+	}
+
+	// RFC 7505 null MX: a single record of "." with priority 0 means the
+	// domain explicitly accepts no mail
+	if len(mxs) == 1 && records[0] == "" && mxs[0].Pref == 0 {
+		return mxCheck{misconfigured: true, reason: "domain publishes a null MX record and accepts no mail", records: records}
+	}
+
+	host := records[0]
+
+	if ip := net.ParseIP(host); ip != nil {
+		return mxCheck{misconfigured: true, reason: fmt.Sprintf("MX host %q is an IP literal, not a hostname", host), records: records}
+	}
+
+	if strings.EqualFold(host, "localhost") {
+		return mxCheck{misconfigured: true, reason: "MX host is localhost", records: records}
+	}
+
+	addrs, err := netLookupHost(host)
+	if err != nil {
+		return mxCheck{misconfigured: true, reason: fmt.Sprintf("MX host %q does not resolve: %s", host, err), records: records}
+	}
+
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			continue
+		}
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() {
+			return mxCheck{misconfigured: true, reason: fmt.Sprintf("MX host %q resolves to a loopback or private address (%s)", host, addr), records: records}
+		}
+	}
+
+	cname, err := netLookupCNAME(host)
+	if err == nil && strings.TrimSuffix(cname, ".") != host {
+		return mxCheck{misconfigured: true, reason: fmt.Sprintf("MX host %q is a CNAME chain to %q, which violates RFC 2181", host, strings.TrimSuffix(cname, ".")), records: records}
+	}
+
+	return mxCheck{records: records}
+}
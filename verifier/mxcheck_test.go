@@ -0,0 +1,44 @@
+package verifier
+
+import (
+	"net"
+	"testing"
+)
+
+func mx(host string, pref uint16) *net.MX {
+	return &net.MX{Host: host, Pref: pref}
+}
+
+func TestCheckMXEmpty(t *testing.T) {
+	result := checkMX(nil)
+	if result.misconfigured {
+		t.Fatal("expected no MX records to not be reported as misconfigured")
+	}
+	if len(result.records) != 0 {
+		t.Fatalf("expected no records, got %v", result.records)
+	}
+}
+
+func TestCheckMXNullMX(t *testing.T) {
+	result := checkMX([]*net.MX{mx(".", 0)})
+	if !result.misconfigured {
+		t.Fatal("expected a null MX record to be reported as misconfigured")
+	}
+}
+
+func TestCheckMXIPLiteral(t *testing.T) {
+	result := checkMX([]*net.MX{mx("192.0.2.1.", 10)})
+	if !result.misconfigured {
+		t.Fatal("expected an IP literal MX host to be reported as misconfigured")
+	}
+	if result.records[0] != "192.0.2.1" {
+		t.Fatalf("expected trailing dot to be trimmed, got %q", result.records[0])
+	}
+}
+
+func TestCheckMXLocalhost(t *testing.T) {
+	result := checkMX([]*net.MX{mx("localhost.", 10)})
+	if !result.misconfigured {
+		t.Fatal("expected a localhost MX host to be reported as misconfigured")
+	}
+}
@@ -0,0 +1,200 @@
+package verifier
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limiter defines a pluggable rate limiting policy consulted before opening
+// an SMTP connection to, or issuing a probe against, a given MX host.
+// Implementations typically combine a per-host token bucket with a global
+// cap so a run targeting a handful of domains can't starve every other
+// in-flight domain, or get the source IP blocklisted by a single provider
+type Limiter interface {
+	// Allow reports whether a request against mxHost may proceed right now.
+	// When it returns false, retryAfter indicates how long the caller
+	// should wait before trying again
+	Allow(mxHost string) (ok bool, retryAfter time.Duration)
+}
+
+// tokenBucketLimiter is the default Limiter, combining an independent token
+// bucket per MX host with a single bucket shared across every host
+type tokenBucketLimiter struct {
+	mu           sync.Mutex
+	perHostRPS   float64
+	perHostBurst int
+	hosts        map[string]*tokenBucket
+	global       *tokenBucket
+}
+
+// NewTokenBucketLimiter returns a Limiter enforcing perHostRPS/perHostBurst
+// against each individual MX host and, if globalRPS is positive, an
+// additional globalRPS/globalBurst cap shared across all hosts. A rps of 0
+// disables that tier of limiting
+func NewTokenBucketLimiter(perHostRPS float64, perHostBurst int, globalRPS float64, globalBurst int) Limiter {
+	l := &tokenBucketLimiter{
+		perHostRPS:   perHostRPS,
+		perHostBurst: perHostBurst,
+		hosts:        make(map[string]*tokenBucket),
+	}
+	if globalRPS > 0 {
+		l.global = newTokenBucket(globalRPS, globalBurst)
+	}
+	return l
+}
+
+// Allow consults the per-host bucket for mxHost, then the global bucket,
+// returning the wait of the first one found exhausted; the global bucket
+// is not consulted when the per-host bucket is exhausted
+func (l *tokenBucketLimiter) Allow(mxHost string) (bool, time.Duration) {
+	var bucket *tokenBucket
+	if l.perHostRPS > 0 {
+		l.mu.Lock()
+		bucket = l.hosts[mxHost]
+		if bucket == nil {
+			bucket = newTokenBucket(l.perHostRPS, l.perHostBurst)
+			l.hosts[mxHost] = bucket
+		}
+		l.mu.Unlock()
+	}
+
+	if bucket != nil {
+		if ok, wait := bucket.take(); !ok {
+			return false, wait
+		}
+	}
+	if l.global != nil {
+		if ok, wait := l.global.take(); !ok {
+			return false, wait
+		}
+	}
+	return true, 0
+}
+
+// tokenBucket is a minimal, mutex-guarded, lazily-refilled token bucket
+type tokenBucket struct {
+	mu       sync.Mutex
+	rps      float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newTokenBucket(rps float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rps: rps, burst: float64(burst), tokens: float64(burst), lastFill: time.Now()}
+}
+
+// take attempts to consume one token, refilling based on elapsed time first
+func (b *tokenBucket) take() (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens = minFloat(b.burst, b.tokens+now.Sub(b.lastFill).Seconds()*b.rps)
+	b.lastFill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rps * float64(time.Second))
+}
+
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// backoffBase and backoffMax bound the exponential backoff applied to an MX
+// host after a temporary-failure SMTP response
+const (
+	backoffBase = 1 * time.Second
+	backoffMax  = 5 * time.Minute
+)
+
+// hostBackoff tracks consecutive temporary failures for a single MX host
+type hostBackoff struct {
+	failures     int
+	blockedUntil time.Time
+}
+
+// backoffTracker records exponential backoff state per MX host, populated
+// whenever an SMTP probe against that host returns a 4xx temp-fail or
+// "try again later" response
+type backoffTracker struct {
+	mu    sync.Mutex
+	hosts map[string]*hostBackoff
+}
+
+func newBackoffTracker() *backoffTracker {
+	return &backoffTracker{hosts: make(map[string]*hostBackoff)}
+}
+
+// retryAfter returns how long the caller must still wait before mxHost is
+// clear of an earlier temporary failure, or 0 if it's already clear
+func (t *backoffTracker) retryAfter(mxHost string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	hb, ok := t.hosts[mxHost]
+	if !ok {
+		return 0
+	}
+	if wait := time.Until(hb.blockedUntil); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// recordTempFail registers a temporary failure for mxHost and returns the
+// backoff duration applied, doubling on every consecutive failure up to
+// backoffMax
+func (t *backoffTracker) recordTempFail(mxHost string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	hb, ok := t.hosts[mxHost]
+	if !ok {
+		hb = &hostBackoff{}
+		t.hosts[mxHost] = hb
+	}
+	wait := backoffBase << hb.failures
+	if wait <= 0 || wait > backoffMax {
+		wait = backoffMax
+	}
+	hb.failures++
+	hb.blockedUntil = time.Now().Add(wait)
+	return wait
+}
+
+// recordSuccess clears any backoff state recorded for mxHost
+func (t *backoffTracker) recordSuccess(mxHost string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.hosts, mxHost)
+}
+
+// isTempFailure reports whether err looks like a transient SMTP rejection
+// (a 4xx reply code or a "try again later" style message) as opposed to a
+// permanent one
+func isTempFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	errStr := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(errStr, "421"),
+		strings.Contains(errStr, "450"),
+		strings.Contains(errStr, "451"),
+		strings.Contains(errStr, "452"),
+		strings.Contains(errStr, "try again"),
+		strings.Contains(errStr, "temporarily"):
+		return true
+	default:
+		return false
+	}
+}
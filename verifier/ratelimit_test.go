@@ -0,0 +1,118 @@
+package verifier
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucketTakeWithinBurst(t *testing.T) {
+	b := newTokenBucket(1, 3)
+	for i := 0; i < 3; i++ {
+		if ok, wait := b.take(); !ok {
+			t.Fatalf("take %d: expected ok, got wait %v", i, wait)
+		}
+	}
+	ok, wait := b.take()
+	if ok {
+		t.Fatal("expected bucket to be exhausted after consuming the full burst")
+	}
+	if wait <= 0 {
+		t.Fatalf("expected a positive retry wait, got %v", wait)
+	}
+}
+
+func TestTokenBucketRefill(t *testing.T) {
+	b := newTokenBucket(1000, 1) // fast rps so the test doesn't need to sleep long
+	if ok, _ := b.take(); !ok {
+		t.Fatal("expected first take to succeed")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ok, wait := b.take(); !ok {
+		t.Fatalf("expected bucket to have refilled after waiting, got wait %v", wait)
+	}
+}
+
+func TestTokenBucketLimiterPerHostIndependence(t *testing.T) {
+	l := NewTokenBucketLimiter(1, 1, 0, 0)
+	if ok, _ := l.Allow("a.example.com"); !ok {
+		t.Fatal("expected first request against a.example.com to be allowed")
+	}
+	if ok, _ := l.Allow("b.example.com"); !ok {
+		t.Fatal("expected a different host's bucket to be independent")
+	}
+	if ok, wait := l.Allow("a.example.com"); ok {
+		t.Fatalf("expected a.example.com's burst to already be exhausted, got wait %v", wait)
+	}
+}
+
+func TestTokenBucketLimiterGlobalCap(t *testing.T) {
+	l := NewTokenBucketLimiter(0, 0, 1, 1)
+	if ok, _ := l.Allow("a.example.com"); !ok {
+		t.Fatal("expected first request to be allowed under the global cap")
+	}
+	if ok, _ := l.Allow("b.example.com"); ok {
+		t.Fatal("expected the global cap to apply across different hosts")
+	}
+}
+
+func TestBackoffTrackerRecordAndRetryAfter(t *testing.T) {
+	bt := newBackoffTracker()
+	if wait := bt.retryAfter("mx.example.com"); wait != 0 {
+		t.Fatalf("expected no backoff before any failure, got %v", wait)
+	}
+
+	first := bt.recordTempFail("mx.example.com")
+	if first != backoffBase {
+		t.Fatalf("expected first backoff to equal backoffBase (%v), got %v", backoffBase, first)
+	}
+	if wait := bt.retryAfter("mx.example.com"); wait <= 0 {
+		t.Fatal("expected retryAfter to report a pending wait right after a temp failure")
+	}
+
+	second := bt.recordTempFail("mx.example.com")
+	if second != 2*backoffBase {
+		t.Fatalf("expected backoff to double on a second consecutive failure, got %v want %v", second, 2*backoffBase)
+	}
+}
+
+func TestBackoffTrackerCapsAtMax(t *testing.T) {
+	bt := newBackoffTracker()
+	var last time.Duration
+	for i := 0; i < 20; i++ {
+		last = bt.recordTempFail("mx.example.com")
+	}
+	if last != backoffMax {
+		t.Fatalf("expected backoff to cap at backoffMax (%v), got %v", backoffMax, last)
+	}
+}
+
+func TestBackoffTrackerRecordSuccessClears(t *testing.T) {
+	bt := newBackoffTracker()
+	bt.recordTempFail("mx.example.com")
+	bt.recordSuccess("mx.example.com")
+	if wait := bt.retryAfter("mx.example.com"); wait != 0 {
+		t.Fatalf("expected recordSuccess to clear backoff state, got wait %v", wait)
+	}
+}
+
+func TestIsTempFailure(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errTest("421 service not available"), true},
+		{errTest("450 mailbox unavailable"), true},
+		{errTest("try again later"), true},
+		{errTest("550 no such user"), false},
+	}
+	for _, c := range cases {
+		if got := isTempFailure(c.err); got != c.want {
+			t.Errorf("isTempFailure(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+type errTest string
+
+func (e errTest) Error() string { return string(e) }
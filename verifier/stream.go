@@ -0,0 +1,186 @@
+package verifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"io"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// streamQueueBuffer bounds how many addresses may queue up for a single
+// domain before VerifyStream blocks reading more input, keeping memory use
+// bounded regardless of input size
+const streamQueueBuffer = 64
+
+// VerifyStream pipelines parsing, domain grouping and validation over in
+// without buffering the entire input in memory. Unlike Verify, addresses
+// for the same domain share one persistent SMTP connection that's drained
+// and closed only once no more addresses for that domain arrive, so a run
+// of thousands of addresses against a handful of domains reuses a handful
+// of TCP sessions rather than opening one per address
+func (v *verifier) VerifyStream(ctx context.Context, in <-chan string) <-chan *Lookup {
+	out := make(chan *Lookup)
+
+	go func() {
+		defer close(out)
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		queues := make(map[string]chan *Address)
+
+		// Bounds the number of concurrently-active domain workers - and
+		// thus concurrently-open SMTP connections - to v.maxWorkerCount, the
+		// same limit Verify enforces, so a stream touching thousands of
+		// distinct domains doesn't open thousands of connections at once
+		sem := make(chan struct{}, v.maxWorkerCount)
+
+		queueFor := func(domain string) chan *Address {
+			mu.Lock()
+			defer mu.Unlock()
+			if q, ok := queues[domain]; ok {
+				return q
+			}
+			q := make(chan *Address, streamQueueBuffer)
+			queues[domain] = q
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+				v.streamWorker(ctx, domain, q, out)
+			}()
+			return q
+		}
+
+	drain:
+		for {
+			select {
+			case <-ctx.Done():
+				break drain
+			case email, ok := <-in:
+				if !ok {
+					break drain
+				}
+				address, err := ParseAddress(email)
+				if err != nil {
+					basicErr, suggestion := "Failed to parse email", ""
+					if err == ErrUnknownTLD {
+						basicErr = "Domain has an unrecognized top level domain"
+						if address != nil {
+							suggestion = address.Suggestion
+						}
+					}
+					out <- &Lookup{Address: email, Suggestion: suggestion, Error: basicErr, ErrorDetails: err.Error()}
+					continue
+				}
+				select {
+				case queueFor(address.Domain) <- address:
+				case <-ctx.Done():
+					break drain
+				}
+			}
+		}
+
+		mu.Lock()
+		for _, q := range queues {
+			close(q)
+		}
+		mu.Unlock()
+		wg.Wait()
+	}()
+
+	return out
+}
+
+// streamWorker drains a single domain's address queue over one persistent
+// SMTP connection, rate limiting requests against that domain and
+// closing the connection once the queue is exhausted or ctx is cancelled.
+// Both dequeuing and sending honor ctx.Done() directly, independent of
+// whether a stream rate limit is configured, so a cancelled ctx always stops
+// in-flight probing promptly rather than only when limiter.Wait happens to
+// be consulted
+func (v *verifier) streamWorker(ctx context.Context, domain string, queue <-chan *Address, out chan<- *Lookup) {
+	limiter := v.streamLimiter()
+
+	var dc *domainContext
+	defer func() {
+		if dc != nil {
+			dc.close()
+		}
+	}()
+
+	for {
+		var address *Address
+		select {
+		case <-ctx.Done():
+			return
+		case a, ok := <-queue:
+			if !ok {
+				return
+			}
+			address = a
+		}
+
+		if dc == nil {
+			dc = v.resolveDomain(domain)
+		}
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				return
+			}
+		}
+
+		lookup := v.lookupAddress(dc, address)
+		select {
+		case out <- lookup:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// streamLimiter builds the token bucket limiter VerifyStream applies per
+// domain worker, or nil when no rate has been configured
+func (v *verifier) streamLimiter() *rate.Limiter {
+	if v.streamRPS <= 0 {
+		return nil
+	}
+	burst := int(v.streamRPS)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(v.streamRPS), burst)
+}
+
+// VerifyCSVColumn streams email addresses out of the given CSV column index
+// of r and verifies them via VerifyStream without buffering the file in
+// memory, closing out once r is exhausted or ctx is cancelled
+func (v *verifier) VerifyCSVColumn(ctx context.Context, r io.Reader, column int) <-chan *Lookup {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		reader := csv.NewReader(bufio.NewReader(r))
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				return
+			}
+			if err != nil || column >= len(record) {
+				continue
+			}
+			select {
+			case in <- record[column]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return v.VerifyStream(ctx, in)
+}
@@ -0,0 +1,160 @@
+package verifier
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeDisposabler struct{}
+
+func (fakeDisposabler) IsDisposable(domain string) bool { return false }
+
+// fakeAPIVerifier supports every MX host and returns a canned Lookup after
+// an optional delay, letting tests exercise streamWorker/lookupAddress
+// without opening a real SMTP connection. It tracks the peak number of
+// concurrently in-flight Check calls so tests can assert on worker bounds
+type fakeAPIVerifier struct {
+	delay       time.Duration
+	deliverable bool
+
+	mu     sync.Mutex
+	active int
+	peak   int
+}
+
+func (f *fakeAPIVerifier) Supports(mxHost string) bool { return true }
+
+func (f *fakeAPIVerifier) Check(domain, username string) (*Lookup, error) {
+	f.mu.Lock()
+	f.active++
+	if f.active > f.peak {
+		f.peak = f.active
+	}
+	f.mu.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mu.Lock()
+	f.active--
+	f.mu.Unlock()
+
+	return &Lookup{Address: username + "@" + domain, Username: username, Domain: domain, Deliverable: f.deliverable}, nil
+}
+
+// newTestVerifier builds a *verifier directly, bypassing NewVerifier's
+// default Disposabler/Limiter wiring, so tests can plug in fakes. Lookups
+// still call HasGravatar
+func newTestVerifier(maxWorkerCount int, av APIVerifier, cache Cache) *verifier {
+	v := &verifier{
+		maxWorkerCount: maxWorkerCount,
+		hostname:       "test.local",
+		sourceAddr:     "probe@test.local",
+		disposabler:    fakeDisposabler{},
+		backoff:        newBackoffTracker(),
+		cache:          cache,
+	}
+	if av != nil {
+		v.apiVerifiers = []APIVerifier{av}
+	}
+	return v
+}
+
+// seedMX pre-populates the MX cache for domain so resolveDomain doesn't
+// perform a real MX lookup. checkMX still resolves the MX host itself;
+// stubMXHostDNS covers that
+func seedMX(v *verifier, domain, mxHost string) {
+	v.cacheSetMX("mx:"+domain, []*net.MX{{Host: mxHost, Pref: 10}})
+}
+
+// stubMXHostDNS makes every MX host resolve to a public address with no
+// CNAME for the rest of the test, so checkMX doesn't flag the seeded,
+// unresolvable MX hosts as misconfigured and lookups reach the APIVerifier
+func stubMXHostDNS(t *testing.T) {
+	oldHost, oldCNAME := netLookupHost, netLookupCNAME
+	t.Cleanup(func() { netLookupHost, netLookupCNAME = oldHost, oldCNAME })
+	netLookupHost = func(host string) ([]string, error) { return []string{"192.0.2.10"}, nil }
+	netLookupCNAME = func(host string) (string, error) { return host + ".", nil }
+}
+
+func TestVerifyStreamBoundsConcurrencyToMaxWorkerCount(t *testing.T) {
+	av := &fakeAPIVerifier{delay: 20 * time.Millisecond, deliverable: true}
+	v := newTestVerifier(1, av, NewLRUCache(0))
+	stubMXHostDNS(t)
+
+	domains := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"}
+	for _, d := range domains {
+		seedMX(v, d, "mx."+d+".")
+	}
+
+	in := make(chan string)
+	out := v.VerifyStream(context.Background(), in)
+
+	go func() {
+		defer close(in)
+		for _, d := range domains {
+			in <- "user@" + d
+		}
+	}()
+
+	var got int
+	for range out {
+		got++
+	}
+	if got != len(domains) {
+		t.Fatalf("got %d lookups, want %d", got, len(domains))
+	}
+	if av.peak > 1 {
+		t.Errorf("peak concurrent Check calls = %d, want <= 1 (maxWorkerCount)", av.peak)
+	}
+}
+
+// TestVerifyStreamSendUnblocksOnCancellation reproduces the scenario where a
+// caller cancels ctx and stops draining the returned channel: a live
+// streamWorker, already blocked trying to send a completed Lookup to an
+// undrained out, must give up via ctx.Done() rather than block forever
+func TestVerifyStreamSendUnblocksOnCancellation(t *testing.T) {
+	av := &fakeAPIVerifier{delay: 0, deliverable: true}
+	v := newTestVerifier(4, av, NewLRUCache(0))
+	stubMXHostDNS(t)
+	seedMX(v, "example.com", "mx.example.com.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	in := make(chan string, 1)
+	in <- "user@example.com"
+	out := v.VerifyStream(ctx, in) // nothing drains out below
+
+	// Give streamWorker time to resolve the domain, compute the lookup and
+	// block on "out <- lookup" since nothing is reading it yet
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Fatal("expected the blocked send to be abandoned, not delivered, after cancellation")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("out was never closed after ctx was cancelled while undrained - streamWorker is stuck on an unconditional send")
+	}
+}
+
+func TestVerifyStreamSurfacesParseErrors(t *testing.T) {
+	v := newTestVerifier(1, nil, nil)
+	in := make(chan string, 1)
+	in <- "not-an-email"
+	close(in)
+
+	out := v.VerifyStream(context.Background(), in)
+	lookup, ok := <-out
+	if !ok {
+		t.Fatal("expected a Lookup for the unparseable address")
+	}
+	if lookup.Error == "" {
+		t.Error("expected Error to be set for an unparseable address")
+	}
+}
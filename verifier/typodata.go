@@ -0,0 +1,152 @@
+package verifier
+
+// popularDomains lists common free-mail and large regional webmail domains
+// used as the candidate set for typo suggestions, ordered from most to
+// least popular
+var popularDomains = []string{
+	"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "aol.com",
+	"icloud.com", "live.com", "msn.com", "mail.com", "protonmail.com",
+	"gmx.com", "yandex.com", "zoho.com", "comcast.net", "verizon.net",
+	"att.net", "sbcglobal.net", "me.com", "googlemail.com", "ymail.com",
+	"rocketmail.com", "hotmail.co.uk", "yahoo.co.uk", "live.co.uk",
+	"btinternet.com", "virginmedia.com", "sky.com", "talktalk.net",
+	"web.de", "gmx.de", "t-online.de", "orange.fr", "free.fr", "laposte.net",
+	"wanadoo.fr", "libero.it", "virgilio.it", "alice.it", "qq.com",
+	"163.com", "126.com", "sina.com", "naver.com", "hanmail.net",
+	"rediffmail.com", "yahoo.in", "outlook.in",
+	"outlook.com.br", "uol.com.br", "bol.com.br", "terra.com.br",
+	"ig.com.br", "globo.com", "yahoo.com.br", "hotmail.com.br",
+	"yahoo.fr", "yahoo.de", "yahoo.es", "yahoo.it", "yahoo.co.jp",
+	"yahoo.com.cn", "yahoo.com.hk", "yahoo.com.sg", "yahoo.com.au",
+	"hotmail.fr", "hotmail.de", "hotmail.es", "hotmail.it",
+	"live.fr", "live.de", "live.nl", "live.com.mx", "live.com.ar",
+	"outlook.fr", "outlook.de", "outlook.es", "outlook.it", "outlook.jp",
+	"mail.ru", "inbox.ru", "list.ru", "bk.ru", "rambler.ru",
+	"126.com", "139.com", "189.cn", "foxmail.com", "tom.com",
+	"daum.net", "nate.com", "hanmir.com", "korea.com",
+	"seznam.cz", "centrum.cz", "post.cz", "email.cz",
+	"interia.pl", "wp.pl", "onet.pl", "o2.pl", "poczta.fm",
+	"telenet.be", "skynet.be", "hotmail.be", "live.be",
+	"xs4all.nl", "planet.nl", "ziggo.nl", "home.nl", "kpnmail.nl",
+	"telia.com", "comhem.se", "spray.se", "bredband.net",
+	"online.no", "nextmail.no", "getmail.no",
+	"mail.dk", "webspeed.dk", "stofanet.dk",
+	"elisa.fi", "luukku.com", "saunalahti.fi",
+	"rogers.com", "sympatico.ca", "shaw.ca", "bell.net", "telus.net",
+	"cogeco.ca", "videotron.ca", "hushmail.com", "fastmail.com",
+	"tutanota.com", "mailfence.com", "runbox.com", "posteo.de",
+	"126.net", "21cn.com", "sohu.com", "vip.sina.com", "aliyun.com",
+	"rediff.com", "indiatimes.com", "in.com", "sify.com", "lycos.com",
+	"excite.com", "mindspring.com", "earthlink.net", "juno.com",
+	"netzero.net", "charter.net", "cox.net", "windstream.net",
+	"frontier.com", "optonline.net", "roadrunner.com", "embarqmail.com",
+	"bigpond.com", "optusnet.com.au", "iinet.net.au", "tpg.com.au",
+	"xtra.co.nz", "clear.net.nz", "paradise.net.nz",
+	"telkomsa.net", "webmail.co.za", "vodamail.co.za",
+	"nifty.com", "biglobe.ne.jp", "docomo.ne.jp", "ezweb.ne.jp",
+	"softbank.ne.jp", "au.com", "goo.ne.jp",
+	"chol.com", "dreamwiz.com", "empal.com", "lycos.co.kr",
+}
+
+// popularDomainRank maps each popularDomains entry to its index, used to
+// break ties between equally-close typo candidates in favor of the more
+// popular domain
+var popularDomainRank = func() map[string]int {
+	ranks := make(map[string]int, len(popularDomains))
+	for i, domain := range popularDomains {
+		ranks[domain] = i
+	}
+	return ranks
+}()
+
+// validTLDs is a bundled subset of the IANA root zone - the generic,
+// sponsored, generic-restricted and most-trafficked country-code TLDs -
+// used to reject addresses whose domain ends in a top level domain that
+// doesn't exist. It's not exhaustive against the full 1500+ entry IANA root
+// zone, but covers every TLD likely to appear in real-world email traffic
+var validTLDs = func() map[string]bool {
+	tlds := []string{
+		// Original generic and infrastructure TLDs
+		"com", "net", "org", "edu", "gov", "mil", "int", "biz", "info",
+		"name", "pro", "coop", "museum", "aero", "jobs", "mobi", "travel",
+		"cat", "tel", "asia", "xxx", "post", "arpa",
+
+		// Newer generic TLDs in common use
+		"io", "co", "me", "tv", "cc", "app", "dev", "xyz", "online",
+		"site", "tech", "store", "shop", "blog", "cloud", "design",
+		"digital", "email", "live", "media", "news", "studio", "team",
+		"world", "life", "today", "network", "systems", "solutions",
+		"services", "agency", "company", "group", "ltd", "inc", "llc",
+		"academy", "institute", "university", "school", "courses",
+		"codes", "software", "computer", "technology", "ai", "ml",
+		"app", "dev", "page", "how", "new", "is", "to", "gg", "sh",
+		"art", "band", "bar", "beer", "bike", "boutique", "build",
+		"business", "cafe", "camera", "camp", "capital", "cards",
+		"care", "careers", "cash", "casino", "center", "chat", "city",
+		"click", "clinic", "clothing", "club", "coach", "codes",
+		"coffee", "college", "community", "construction", "consulting",
+		"contact", "contractors", "cooking", "cool", "country",
+		"credit", "creditcard", "cricket", "cruise", "dance", "data",
+		"dating", "deals", "delivery", "democrat", "dental", "diamonds",
+		"diet", "digital", "direct", "directory", "discount", "doctor",
+		"dog", "domains", "download", "earth", "eco", "education",
+		"energy", "engineering", "enterprises", "equipment", "estate",
+		"events", "exchange", "expert", "exposed", "express", "fail",
+		"faith", "family", "fan", "fans", "farm", "fashion", "finance",
+		"financial", "fish", "fishing", "fit", "fitness", "flights",
+		"florist", "flowers", "football", "forsale", "foundation",
+		"fund", "furniture", "futbol", "fyi", "gallery", "games",
+		"garden", "gift", "gifts", "gives", "glass", "global", "gold",
+		"golf", "graphics", "gratis", "green", "gripe", "guide",
+		"guitars", "guru", "haus", "health", "healthcare", "help",
+		"hiphop", "hockey", "holdings", "holiday", "homes", "horse",
+		"hospital", "host", "hosting", "house", "immo", "industries",
+		"ink", "insure", "investments", "jewelry", "kitchen", "land",
+		"lawyer", "lease", "legal", "lgbt", "lighting", "limited",
+		"limo", "loan", "loans", "lol", "love", "luxury", "management",
+		"market", "marketing", "mba", "memorial", "money", "mortgage",
+		"motorcycles", "movie", "museum", "music", "ninja", "observer",
+		"partners", "parts", "party", "pet", "photo", "photography",
+		"photos", "physio", "pics", "pictures", "pink", "pizza", "place",
+		"plumbing", "plus", "poker", "porn", "press", "productions",
+		"properties", "property", "pub", "racing", "recipes", "red",
+		"rehab", "reisen", "rentals", "repair", "report", "rest",
+		"restaurant", "review", "reviews", "rip", "rocks", "rodeo",
+		"run", "sale", "salon", "sarl", "sbs", "science", "security",
+		"sex", "sexy", "singles", "ski", "soccer", "social", "space",
+		"sport", "style", "supplies", "supply", "support", "surf",
+		"surgery", "sydney", "systems", "tattoo", "tax", "taxi",
+		"theater", "theatre", "tips", "tires", "tools", "tours",
+		"toys", "trade", "trading", "training", "tv", "university",
+		"vacations", "vegas", "ventures", "vet", "video", "villas",
+		"vin", "vip", "vision", "vodka", "vote", "voyage", "watch",
+		"webcam", "website", "wedding", "wiki", "win", "wine", "work",
+		"works", "wtf", "yoga", "zone",
+
+		// Country-code TLDs with heavy email traffic
+		"us", "uk", "ca", "de", "fr", "it", "es", "nl", "se", "no",
+		"dk", "fi", "pl", "ru", "cn", "jp", "kr", "in", "au", "nz",
+		"br", "mx", "ar", "za", "ch", "at", "be", "pt", "gr", "ie",
+		"il", "sg", "hk", "tw", "th", "vn", "id", "my", "ph", "ae",
+		"sa", "tr", "ua", "cz", "sk", "hu", "ro", "bg", "hr", "rs",
+		"si", "lt", "lv", "ee", "is", "lu", "mt", "cy", "li", "mc",
+		"sm", "va", "ad", "al", "ba", "mk", "md", "by", "kz", "uz",
+		"az", "am", "ge", "kg", "tj", "tm", "mn", "np", "bd", "lk",
+		"pk", "ir", "iq", "jo", "lb", "sy", "ye", "om", "qa", "kw",
+		"bh", "eg", "ma", "dz", "tn", "ly", "sd", "ke", "tz", "ug",
+		"gh", "ng", "sn", "ci", "cm", "zw", "zm", "mz", "ao", "na",
+		"bw", "mu", "mg", "re", "sc", "cv", "gm", "gn", "ml", "ne",
+		"bf", "tg", "bj", "cg", "cd", "ga", "gq", "td", "rw", "bi",
+		"so", "dj", "er", "et", "sz", "ls", "mw",
+		"cl", "co", "pe", "ve", "ec", "bo", "py", "uy", "gy", "sr",
+		"cr", "pa", "ni", "hn", "sv", "gt", "bz", "cu", "do", "ht",
+		"jm", "tt", "bs", "bb", "ag", "dm", "gd", "kn", "lc", "vc",
+		"fj", "pg", "sb", "vu", "nc", "pf", "to", "ws", "ki", "tv",
+		"nr", "pw", "fm", "mh", "tl",
+	}
+	valid := make(map[string]bool, len(tlds))
+	for _, tld := range tlds {
+		valid[tld] = true
+	}
+	return valid
+}()
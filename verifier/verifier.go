@@ -1,31 +1,57 @@
 package verifier
 
 import (
+	"context"
 	"encoding/xml"
+	"io"
+	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 )
 
+// Cache TTLs applied to the various values verifier memoizes
+const (
+	mxCacheTTL          = 1 * time.Hour
+	catchAllCacheTTL    = 1 * time.Hour
+	disposableCacheTTL  = 24 * time.Hour
+	deliverableCacheTTL = 30 * time.Minute
+)
+
 // Lookup contains all output data for an email validation Lookup
 type Lookup struct {
-	XMLName      xml.Name `json:"-" xml:"lookup"`
-	Address      string   `json:"address,omitempty" xml:"address,omitempty"`
-	Username     string   `json:"username,omitempty" xml:"username,omitempty"`
-	Domain       string   `json:"domain,omitempty" xml:"domain,omitempty"`
-	HostExists   bool     `json:"hostExists" xml:"hostExists"`
-	Deliverable  bool     `json:"deliverable" xml:"deliverable"`
-	FullInbox    bool     `json:"fullInbox" xml:"fullInbox"`
-	CatchAll     bool     `json:"catchAll" xml:"catchAll"`
-	Disposable   bool     `json:"disposable" xml:"disposable"`
-	Gravatar     bool     `json:"gravatar" xml:"gravatar"`
-	Error        string   `json:"error,omitempty" xml:"error,omitempty"`
-	ErrorDetails string   `json:"errorDetails,omitempty" xml:"errorDetails,omitempty"`
+	XMLName         xml.Name      `json:"-" xml:"lookup"`
+	Address         string        `json:"address,omitempty" xml:"address,omitempty"`
+	Username        string        `json:"username,omitempty" xml:"username,omitempty"`
+	Domain          string        `json:"domain,omitempty" xml:"domain,omitempty"`
+	HostExists      bool          `json:"hostExists" xml:"hostExists"`
+	Deliverable     bool          `json:"deliverable" xml:"deliverable"`
+	FullInbox       bool          `json:"fullInbox" xml:"fullInbox"`
+	CatchAll        bool          `json:"catchAll" xml:"catchAll"`
+	Disposable      bool          `json:"disposable" xml:"disposable"`
+	Gravatar        bool          `json:"gravatar" xml:"gravatar"`
+	MisconfiguredMX bool          `json:"misconfiguredMX" xml:"misconfiguredMX"`
+	MXRecords       []string      `json:"mxRecords,omitempty" xml:"mxRecords,omitempty"`
+	Suggestion      string        `json:"suggestion,omitempty" xml:"suggestion,omitempty"`
+	RateLimited     bool          `json:"rateLimited,omitempty" xml:"rateLimited,omitempty"`
+	RetryAfter      time.Duration `json:"retryAfter,omitempty" xml:"retryAfter,omitempty"`
+	Error           string        `json:"error,omitempty" xml:"error,omitempty"`
+	ErrorDetails    string        `json:"errorDetails,omitempty" xml:"errorDetails,omitempty"`
 }
 
 // Verifier defines all functionality for fully validating email addresses
 type Verifier interface {
 	Verify(emails ...string) []*Lookup
+
+	// VerifyStream pipelines parsing, domain grouping and validation over
+	// in without buffering the full input, honouring ctx cancellation
+	VerifyStream(ctx context.Context, in <-chan string) <-chan *Lookup
+
+	// VerifyCSVColumn streams and verifies the emails found in the given
+	// column index of a CSV file without buffering it in memory
+	VerifyCSVColumn(ctx context.Context, r io.Reader, column int) <-chan *Lookup
 }
 
 // verifier contains all data needed to perform educated email verification
@@ -35,16 +61,103 @@ type verifier struct {
 	hostname       string // This machines hostname
 	sourceAddr     string // The source email address
 	disposabler    Disposabler
+	apiVerifiers   []APIVerifier // Provider-specific verifiers consulted before falling back to SMTP
+	cache          Cache         // Memoizes MX, catch-all, disposable and deliverability lookups
+	dialer         *net.Dialer   // Base dialer used for timeouts, local address and IPv4-only mode
+	proxyURI       string        // Static socks5:// or http:// proxy used for all SMTP probes
+	proxySelector  ProxySelector // Optional per-MX-host proxy selection, takes precedence over proxyURI
+	streamRPS      float64       // Per-domain requests/sec applied by VerifyStream, 0 means unlimited
+	limiter        Limiter       // Rate limiter consulted before dialing and probing each MX host
+	perHostRPS     float64       // Default per-host token bucket rate, used to build limiter if set
+	perHostBurst   int           // Default per-host token bucket burst
+	globalRPS      float64       // Default global token bucket rate, used to build limiter if set
+	globalBurst    int           // Default global token bucket burst
+	backoff        *backoffTracker
+}
+
+// Option configures optional verifier behavior and is passed to NewVerifier
+type Option func(*verifier)
+
+// WithAPIVerifiers registers one or more APIVerifiers that are consulted, in
+// order, after MX lookup and before SMTP probing so providers with
+// unreliable RCPT TO behavior can be short-circuited
+func WithAPIVerifiers(apiVerifiers ...APIVerifier) Option {
+	return func(v *verifier) { v.apiVerifiers = apiVerifiers }
+}
+
+// WithCache registers a Cache used to memoize MX, catch-all, disposable and
+// per-address deliverability lookups across calls to Verify. Without it,
+// every lookup hits the network
+func WithCache(cache Cache) Option {
+	return func(v *verifier) { v.cache = cache }
+}
+
+// WithDialer overrides the net.Dialer used to open SMTP connections,
+// allowing callers to configure timeouts, a local bind address or
+// IPv4-only dialing
+func WithDialer(dialer *net.Dialer) Option {
+	return func(v *verifier) { v.dialer = dialer }
+}
+
+// WithProxy routes all SMTP probes through the given socks5:// or http://
+// proxy URI, which is useful for senders that need to keep probing traffic
+// off their main egress IP
+func WithProxy(proxyURI string) Option {
+	return func(v *verifier) { v.proxyURI = proxyURI }
+}
+
+// WithProxySelector registers a ProxySelector that chooses a proxy URI per
+// destination MX host, taking precedence over WithProxy. This lets callers
+// rotate egress IPs per domain so probing one provider can't get every
+// other probe blocklisted
+func WithProxySelector(selector ProxySelector) Option {
+	return func(v *verifier) { v.proxySelector = selector }
+}
+
+// WithStreamRateLimit caps VerifyStream to rps requests per second against
+// any single domain, preventing a large run targeting a handful of domains
+// from triggering provider throttling. Each domain has its own limit, so
+// domains that share an MX host are not limited together
+func WithStreamRateLimit(rps float64) Option {
+	return func(v *verifier) { v.streamRPS = rps }
+}
+
+// WithLimiter registers a custom Limiter consulted before every SMTP
+// connection and probe. It takes precedence over WithPerHostLimit and
+// WithGlobalLimit
+func WithLimiter(limiter Limiter) Option {
+	return func(v *verifier) { v.limiter = limiter }
+}
+
+// WithPerHostLimit caps SMTP connections and probes to rps requests per
+// second (with the given burst) against any single MX host, protecting
+// against getting the source IP blocklisted by a single provider
+func WithPerHostLimit(rps float64, burst int) Option {
+	return func(v *verifier) { v.perHostRPS, v.perHostBurst = rps, burst }
+}
+
+// WithGlobalLimit caps SMTP connections and probes to rps requests per
+// second (with the given burst) across every MX host combined
+func WithGlobalLimit(rps float64, burst int) Option {
+	return func(v *verifier) { v.globalRPS, v.globalBurst = rps, burst }
 }
 
 // NewVerifier generates a new AddressVerifier reference
-func NewVerifier(maxWorkerCount int, hostname, sourceAddr string) Verifier {
-	return &verifier{
+func NewVerifier(maxWorkerCount int, hostname, sourceAddr string, opts ...Option) Verifier {
+	v := &verifier{
 		maxWorkerCount: maxWorkerCount,
 		hostname:       hostname,
 		sourceAddr:     sourceAddr,
 		disposabler:    NewDisposabler(),
+		backoff:        newBackoffTracker(),
+	}
+	for _, opt := range opts {
+		opt(v)
 	}
+	if v.limiter == nil && (v.perHostRPS > 0 || v.globalRPS > 0) {
+		v.limiter = NewTokenBucketLimiter(v.perHostRPS, v.perHostBurst, v.globalRPS, v.globalBurst)
+	}
+	return v
 }
 
 // Verify performs all threaded operations involved with validating
@@ -58,8 +171,19 @@ func (v *verifier) Verify(emails ...string) []*Lookup {
 	for _, email := range emails {
 		address, err := ParseAddress(email)
 		if err != nil {
+			basicErr, detailErr := "Failed to parse email", err.Error()
+			var suggestion string
+			if err == ErrUnknownTLD {
+				basicErr = "Domain has an unrecognized top level domain"
+				if address != nil {
+					suggestion = address.Suggestion
+				}
+			}
 			lookups = append(lookups, &Lookup{
-				Error: "Failed to parse email",
+				Address:      email,
+				Suggestion:   suggestion,
+				Error:        basicErr,
+				ErrorDetails: detailErr,
 			})
 			continue
 		}
@@ -107,73 +231,369 @@ func (v *verifier) Verify(emails ...string) []*Lookup {
 // and the deliverabler connection is closed once finished
 func (v *verifier) worker(jobs <-chan []*Address, results chan<- *Lookup) {
 	for j := range jobs {
-		var deliverabler Deliverabler
-		// Defines the domain specific constant variables
-		var disposable, catchAll bool
-		var basicErr, detailErr string
-
-		// Attempts to form an SMTP Connection and returns either a Deliverabler
-		// or an error which will be parsed and returned in the lookup
-		deliverabler, err := NewDeliverabler(j[0].Domain, v.hostname, v.sourceAddr)
-		if err != nil {
-			basicErr, detailErr = parseErr(err)
+		dc := v.resolveDomain(j[0].Domain)
+		for _, address := range j {
+			results <- v.lookupAddress(dc, address)
 		}
+		dc.close()
+	}
+}
 
-		// Retrieves the catchall status if there's a deliverabler and we don't yet
-		// have any catchall status
-		if deliverabler != nil {
-			if deliverabler.HasCatchAll(j[0].Domain, 5) {
-				catchAll = true
-			}
+// domainContext holds everything that's resolved once per domain - its MX
+// sanity check, any matching APIVerifier and its disposable status - so it
+// can be reused across every address in that domain rather than re-resolved
+// per address. Its SMTP connection is established lazily by ensureConnected,
+// which is safe to call before every address: it's a no-op once connected,
+// and retries a connection that was previously skipped due to rate limiting
+// once the limiter/backoff clears. This matters for long-lived queues, such
+// as the per-domain streamWorker, where a transient rate limit must not
+// permanently blackhole every address that arrives afterward
+type domainContext struct {
+	domain       string
+	mxHost       string
+	deliverabler Deliverabler
+	apiVerifier  APIVerifier
+	disposable   bool
+	catchAll     bool
+	mxc          mxCheck
+	dialFailed   bool // a non-rate-limit dial/connect error occurred; don't keep retrying
+	basicErr     string
+	detailErr    string
+}
+
+// resolveDomain performs all of the once-per-domain work that doesn't
+// depend on rate limiting: MX sanity checking, APIVerifier selection and
+// disposable-domain status. It also makes an initial attempt to connect via
+// ensureConnected so catch-all detection is ready before the first address,
+// but callers processing a long-lived queue should call ensureConnected
+// again before every subsequent address
+func (v *verifier) resolveDomain(domain string) *domainContext {
+	dc := &domainContext{domain: domain}
+
+	// Resolves and sanity checks the domain's MX records up front so an
+	// obviously unusable MX (null MX, loopback/private target, dangling
+	// CNAME, ...) can skip the SMTP probe entirely instead of timing out
+	mxs, _ := v.lookupMX(domain)
+	dc.mxc = checkMX(mxs)
+	if dc.mxc.misconfigured {
+		dc.basicErr, dc.detailErr = "Mail server is misconfigured", dc.mxc.reason
+	}
+	if len(dc.mxc.records) > 0 {
+		dc.mxHost = dc.mxc.records[0]
+	}
+
+	// Finds the APIVerifier, if any, registered for this domain's MX host
+	// so SMTP probing can be skipped for providers that handle it poorly
+	dc.apiVerifier = v.apiVerifierFor(domain)
+
+	v.ensureConnected(dc)
+
+	if cached, ok := v.cacheGetBool("disposable:" + domain); ok {
+		dc.disposable = cached
+	} else {
+		dc.disposable = v.disposabler.IsDisposable(domain)
+		v.cacheSetBool("disposable:"+domain, dc.disposable, disposableCacheTTL)
+	}
+
+	return dc
+}
+
+// ensureConnected (re)establishes dc's SMTP connection and catch-all status
+// if that hasn't already succeeded. It's a cheap no-op once dc.deliverabler
+// is set, once a non-rate-limit dial error has already been recorded, or
+// when an APIVerifier will be used or the MX is misconfigured. Otherwise it
+// re-checks the limiter/backoff state fresh on every call - rather than
+// trusting a verdict cached at the moment the domain was first resolved -
+// so a connection attempt that was skipped while rate limited is retried
+// once that limit clears. Returns whether the domain is currently rate
+// limited and, if so, how long the caller should wait before retrying
+func (v *verifier) ensureConnected(dc *domainContext) (rateLimited bool, retryAfter time.Duration) {
+	if dc.apiVerifier != nil || dc.mxc.misconfigured || dc.deliverabler != nil || dc.dialFailed {
+		return false, 0
+	}
+
+	if wait := v.backoff.retryAfter(dc.mxHost); wait > 0 {
+		return true, wait
+	}
+	if v.limiter != nil {
+		if ok, wait := v.limiter.Allow(dc.mxHost); !ok {
+			return true, wait
 		}
-		disposable = v.disposabler.IsDisposable(j[0].Domain)
+	}
 
-		// Builds a validation for every email defined for the domain
-		for _, address := range j {
-			// Performs address specific validation
-			var deliverable, fullInbox, gravatar bool
-			var g errgroup.Group
-
-			// Concurrently retrieve final validation info
-			g.Go(func() error {
-				if catchAll {
-					deliverable = true // Catchall domains will always be deliverable
-				} else if deliverabler != nil {
-					if err := deliverabler.IsDeliverable(address.Address, 5); err == nil {
-						deliverable = true
-					} else if err == ErrFullInbox {
-						fullInbox = true
-					}
-				}
+	// Checked before dialing: a domain already known to be catch-all needs
+	// no SMTP connection at all, since every address on it is trivially
+	// deliverable. Dialing only to immediately discard the connection would
+	// defeat the whole point of caching catch-all status
+	cachedCatchAll, catchAllCached := v.cacheGetBool("catchall:" + dc.domain)
+	if catchAllCached && cachedCatchAll {
+		dc.catchAll = true
+		return false, 0
+	}
+
+	dialer, err := dialerFor(v.dialer, v.proxyURI, v.proxySelector, dc.mxHost)
+	if err != nil {
+		dc.basicErr, dc.detailErr = parseErr(err)
+		dc.dialFailed = true
+		return false, 0
+	}
+	deliverabler, err := NewDeliverabler(dc.domain, v.hostname, v.sourceAddr, dialer)
+	if err != nil {
+		dc.basicErr, dc.detailErr = parseErr(err)
+		dc.dialFailed = true
+		return false, 0
+	}
+	dc.deliverabler = deliverabler
+
+	if catchAllCached {
+		dc.catchAll = cachedCatchAll
+	} else {
+		dc.catchAll = dc.deliverabler.HasCatchAll(dc.domain, 5)
+		v.cacheSetBool("catchall:"+dc.domain, dc.catchAll, catchAllCacheTTL)
+	}
+	return false, 0
+}
+
+// close releases the domain's SMTP connection, if one was opened
+func (dc *domainContext) close() {
+	if dc.deliverabler != nil {
+		dc.deliverabler.Close()
+	}
+}
+
+// lookupAddress performs the address-specific validation against an
+// already-resolved domainContext and returns the completed Lookup
+func (v *verifier) lookupAddress(dc *domainContext, address *Address) *Lookup {
+	var deliverable, fullInbox, gravatar, rateLimited bool
+	var retryAfter time.Duration
+	var g errgroup.Group
+
+	// Concurrently retrieve final validation info
+	g.Go(func() error {
+		deliverableKey := "deliverable:" + address.Address
+		if dc.catchAll {
+			deliverable = true // Catchall domains will always be deliverable
+			return nil
+		}
+		if cached, ok := v.cacheGetDeliverability(deliverableKey); ok {
+			deliverable, fullInbox = cached.deliverable, cached.fullInbox
+			return nil
+		}
+		if dc.mxc.misconfigured {
+			return nil // dc.detailErr already explains why
+		}
+
+		if dc.apiVerifier != nil {
+			// Applies the same per-host limiter the SMTP path uses, since a
+			// provider with an APIVerifier is exactly the kind most likely
+			// to throttle or blocklist repeated probing
+			if wait := v.backoff.retryAfter(dc.mxHost); wait > 0 {
+				rateLimited, retryAfter = true, wait
 				return nil
-			})
-			g.Go(func() error {
-				gravatar = HasGravatar(address)
+			}
+			if v.limiter != nil {
+				if ok, wait := v.limiter.Allow(dc.mxHost); !ok {
+					rateLimited, retryAfter = true, wait
+					return nil
+				}
+			}
+			lookup, err := dc.apiVerifier.Check(address.Domain, address.Username)
+			if err == nil && lookup != nil {
+				deliverable = lookup.Deliverable
+				fullInbox = lookup.FullInbox
+				v.backoff.recordSuccess(dc.mxHost)
+				v.cacheSetDeliverability(deliverableKey, deliverable, fullInbox)
+			} else if isTempFailure(err) {
+				rateLimited, retryAfter = true, v.backoff.recordTempFail(dc.mxHost)
+			}
+			return nil
+		}
+
+		// Re-checks rate limiting and lazily (re)connects on every address
+		// instead of trusting a one-time verdict, so a long-lived queue
+		// (streamWorker) recovers once the limiter/backoff clears rather
+		// than blackholing every address that arrives after the first
+		if limited, wait := v.ensureConnected(dc); limited {
+			rateLimited, retryAfter = true, wait
+			return nil
+		}
+		if dc.deliverabler == nil {
+			return nil // dc.detailErr already explains the connect failure
+		}
+		if v.limiter != nil {
+			if ok, wait := v.limiter.Allow(dc.mxHost); !ok {
+				rateLimited, retryAfter = true, wait
 				return nil
-			})
-			g.Wait()
-
-			// Add each new validation Lookup to the results channel
-			results <- &Lookup{
-				Address:      address.Address,
-				Username:     address.Username,
-				Domain:       address.Domain,
-				HostExists:   !strings.Contains(detailErr, "no such host"),
-				Deliverable:  deliverable,
-				FullInbox:    fullInbox,
-				Disposable:   disposable,
-				CatchAll:     catchAll,
-				Gravatar:     gravatar,
-				Error:        basicErr,
-				ErrorDetails: detailErr,
 			}
 		}
+		if err := dc.deliverabler.IsDeliverable(address.Address, 5); err == nil {
+			deliverable = true
+			v.backoff.recordSuccess(dc.mxHost)
+		} else if err == ErrFullInbox {
+			fullInbox = true
+			v.backoff.recordSuccess(dc.mxHost)
+		} else if isTempFailure(err) {
+			rateLimited, retryAfter = true, v.backoff.recordTempFail(dc.mxHost)
+		}
+		v.cacheSetDeliverability(deliverableKey, deliverable, fullInbox)
+		return nil
+	})
+	g.Go(func() error {
+		gravatar = HasGravatar(address)
+		return nil
+	})
+	g.Wait()
+
+	return &Lookup{
+		Address:         address.Address,
+		Username:        address.Username,
+		Domain:          address.Domain,
+		HostExists:      !strings.Contains(dc.detailErr, "no such host"),
+		Deliverable:     deliverable,
+		RateLimited:     rateLimited,
+		RetryAfter:      retryAfter,
+		FullInbox:       fullInbox,
+		Disposable:      dc.disposable,
+		CatchAll:        dc.catchAll,
+		Gravatar:        gravatar,
+		MisconfiguredMX: dc.mxc.misconfigured,
+		MXRecords:       dc.mxc.records,
+		Suggestion:      address.Suggestion,
+		Error:           dc.basicErr,
+		ErrorDetails:    dc.detailErr,
+	}
+}
 
-		// Close the connection with the MX server now that we are finished
-		if deliverabler != nil {
-			deliverabler.Close()
+// apiVerifierFor resolves domain's MX host and returns the first registered
+// APIVerifier that supports it, or nil if none match or the MX lookup fails
+func (v *verifier) apiVerifierFor(domain string) APIVerifier {
+	if len(v.apiVerifiers) == 0 {
+		return nil
+	}
+	mxs, err := v.lookupMX(domain)
+	if err != nil || len(mxs) == 0 {
+		return nil
+	}
+	mxHost := strings.TrimSuffix(mxs[0].Host, ".")
+	for _, av := range v.apiVerifiers {
+		if av.Supports(mxHost) {
+			return av
 		}
 	}
+	return nil
+}
+
+// lookupMX resolves domain's MX records, consulting and populating the
+// cache first so repeated lookups for the same domain don't re-hit DNS
+func (v *verifier) lookupMX(domain string) ([]*net.MX, error) {
+	key := "mx:" + domain
+	if cached, ok := v.cacheGetMX(key); ok {
+		return cached, nil
+	}
+	mxs, err := net.LookupMX(domain)
+	if err == nil {
+		v.cacheSetMX(key, mxs)
+	}
+	return mxs, err
+}
+
+// cacheGetBool reads a cached boolean, returning ok=false if there's no
+// cache configured or the key isn't present
+func (v *verifier) cacheGetBool(key string) (bool, bool) {
+	if v.cache == nil {
+		return false, false
+	}
+	b, ok := v.cache.Get(key)
+	if !ok {
+		return false, false
+	}
+	return string(b) == "1", true
+}
+
+// cacheSetBool writes a cached boolean, a no-op if there's no cache configured
+func (v *verifier) cacheSetBool(key string, val bool, ttl time.Duration) {
+	if v.cache == nil {
+		return
+	}
+	if val {
+		v.cache.Set(key, []byte("1"), ttl)
+	} else {
+		v.cache.Set(key, []byte("0"), ttl)
+	}
+}
+
+// deliverability is the cached shape of a single address's deliverability
+// verdict
+type deliverability struct {
+	deliverable bool
+	fullInbox   bool
+}
+
+// cacheGetDeliverability reads a cached per-address deliverability verdict
+func (v *verifier) cacheGetDeliverability(key string) (deliverability, bool) {
+	if v.cache == nil {
+		return deliverability{}, false
+	}
+	b, ok := v.cache.Get(key)
+	if !ok || len(b) != 2 {
+		return deliverability{}, false
+	}
+	return deliverability{deliverable: b[0] == '1', fullInbox: b[1] == '1'}, true
+}
+
+// cacheSetDeliverability writes a per-address deliverability verdict
+func (v *verifier) cacheSetDeliverability(key string, deliverable, fullInbox bool) {
+	if v.cache == nil {
+		return
+	}
+	v.cache.Set(key, []byte{boolByte(deliverable), boolByte(fullInbox)}, deliverableCacheTTL)
+}
+
+// boolByte encodes a bool as the ASCII byte '1' or '0'
+func boolByte(b bool) byte {
+	if b {
+		return '1'
+	}
+	return '0'
+}
+
+// cacheGetMX reads cached MX records, encoded as "host:pref,host:pref,..."
+func (v *verifier) cacheGetMX(key string) ([]*net.MX, bool) {
+	if v.cache == nil {
+		return nil, false
+	}
+	b, ok := v.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if len(b) == 0 {
+		return []*net.MX{}, true
+	}
+	var mxs []*net.MX
+	for _, rec := range strings.Split(string(b), ",") {
+		parts := strings.SplitN(rec, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		pref, err := strconv.ParseUint(parts[1], 10, 16)
+		if err != nil {
+			continue
+		}
+		mxs = append(mxs, &net.MX{Host: parts[0], Pref: uint16(pref)})
+	}
+	return mxs, true
+}
+
+// cacheSetMX stores MX records as "host:pref,host:pref,..."
+func (v *verifier) cacheSetMX(key string, mxs []*net.MX) {
+	if v.cache == nil {
+		return
+	}
+	recs := make([]string, len(mxs))
+	for i, mx := range mxs {
+		recs[i] = mx.Host + ":" + strconv.FormatUint(uint64(mx.Pref), 10)
+	}
+	v.cache.Set(key, []byte(strings.Join(recs, ",")), mxCacheTTL)
 }
 
 // parseErr parses an error in order to return a more user friendly version of